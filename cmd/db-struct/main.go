@@ -0,0 +1,22 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	dbstruct "github.com/Licoy/db-struct"
+)
+
+func main() {
+	configPath := flag.String("c", "", "配置文件路径（JSON/YAML），如 db-struct -c config.yaml")
+	flag.Parse()
+
+	if *configPath == "" {
+		log.Fatal("请通过 -c 指定配置文件路径")
+	}
+
+	ds := dbstruct.NewDBStruct().LoadConfig(*configPath)
+	if err := ds.Generate(); err != nil {
+		log.Fatalf("生成失败：%s\n", err.Error())
+	}
+}
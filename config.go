@@ -0,0 +1,138 @@
+package dbstruct
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+//Config 对应 -c config.yaml/config.json 的内容，允许不写 builder 调用链直接驱动生成
+type Config struct {
+	Dsn           string                    `json:"dsn" yaml:"dsn"`
+	Driver        string                    `json:"driver" yaml:"driver"`
+	PackageName   string                    `json:"package_name" yaml:"package_name"`
+	ModelPath     string                    `json:"model_path" yaml:"model_path"`
+	SingleFile    bool                      `json:"single_file" yaml:"single_file"`
+	TagJson       bool                      `json:"tag_json" yaml:"tag_json"`
+	TagOrm        bool                      `json:"tag_orm" yaml:"tag_orm"`
+	TagOrmFlavor  string                    `json:"tag_orm_flavor" yaml:"tag_orm_flavor"` //"xorm" 或 "gorm"，设置后改用 NewORMTag 渲染富标签，优先于 tag_orm
+	NullMode      NullMode                  `json:"null_mode" yaml:"null_mode"`
+	MigrationPath string                    `json:"migration_path" yaml:"migration_path"`
+	TypeMap       map[string]string         `json:"type_map" yaml:"type_map"`
+	TypeImports   map[string]string         `json:"type_imports" yaml:"type_imports"`
+	Tables        map[string]*TableOverride `json:"tables" yaml:"tables"`
+}
+
+//TableOverride 描述某张表的个性化生成规则
+type TableOverride struct {
+	StructName  string            `json:"struct_name" yaml:"struct_name"`
+	SkipColumns []string          `json:"skip_columns" yaml:"skip_columns"`
+	ColumnTypes map[string]string `json:"column_types" yaml:"column_types"`
+	ExtraTags   map[string]string `json:"extra_tags" yaml:"extra_tags"`
+}
+
+//LoadConfig 从 JSON 或 YAML 配置文件加载生成参数，按文件后缀区分格式
+func (ds *dbStruct) LoadConfig(path string) *dbStruct {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		ds.err = err
+		return ds
+	}
+
+	cfg := &Config{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, cfg)
+	default:
+		err = json.Unmarshal(data, cfg)
+	}
+	if err != nil {
+		ds.err = fmt.Errorf("解析配置文件%s失败：%s", path, err.Error())
+		return ds
+	}
+
+	ds.applyConfig(cfg)
+	return ds
+}
+
+//applyConfig 将配置内容套用到 dbStruct 上，与手写 builder 调用链走同一套 setter
+func (ds *dbStruct) applyConfig(cfg *Config) {
+	if cfg.Dsn != "" {
+		ds.Dsn(cfg.Dsn)
+	}
+	if cfg.Driver != "" {
+		ds.Driver(cfg.Driver)
+	}
+	if cfg.PackageName != "" {
+		ds.PackageName(cfg.PackageName)
+	}
+	if cfg.ModelPath != "" {
+		ds.modelPath = cfg.ModelPath
+	}
+	if cfg.SingleFile {
+		ds.SingleFile(true)
+	}
+	if cfg.TagJson {
+		ds.TagJson(true)
+	}
+	switch strings.ToLower(cfg.TagOrmFlavor) {
+	case "xorm":
+		ds.AppendTag(NewORMTag("xorm", XormFlavor))
+	case "gorm":
+		ds.AppendTag(NewORMTag("gorm", GormFlavor))
+	default:
+		if cfg.TagOrm {
+			ds.TagOrm(true)
+		}
+	}
+	ds.NullMode(cfg.NullMode)
+	if cfg.MigrationPath != "" {
+		ds.MigrationPath(cfg.MigrationPath)
+	}
+	if len(cfg.TypeMap) > 0 {
+		ds.typeOverrides = make(map[string]string, len(cfg.TypeMap))
+		for dbType, goType := range cfg.TypeMap {
+			ds.typeOverrides[strings.ToLower(dbType)] = goType
+		}
+	}
+	if len(cfg.TypeImports) > 0 {
+		ds.typeImports = make(map[string]string, len(cfg.TypeImports))
+		for goType, importPath := range cfg.TypeImports {
+			ds.typeImports[goType] = importPath
+		}
+	}
+	if len(cfg.Tables) > 0 {
+		ds.tableOverrides = cfg.Tables
+	}
+}
+
+//filterSkippedColumns 按表的 skip_columns 配置过滤掉不需要生成的字段
+func (ds *dbStruct) filterSkippedColumns(columns []column, override *TableOverride) []column {
+	if override == nil || len(override.SkipColumns) == 0 {
+		return columns
+	}
+	skip := make(map[string]bool, len(override.SkipColumns))
+	for _, name := range override.SkipColumns {
+		skip[name] = true
+	}
+	filtered := make([]column, 0, len(columns))
+	for _, c := range columns {
+		if skip[c.Name] {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	return filtered
+}
+
+//appendTagExtra 把 extra_tags 配置的原始内容拼接进已渲染好的结构体 tag 中
+func appendTagExtra(tags string, extra string) string {
+	if tags == "" {
+		return fmt.Sprintf("`%s`", extra)
+	}
+	return strings.TrimSuffix(tags, "`") + extra + "`"
+}
@@ -5,7 +5,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	_ "github.com/go-sql-driver/mysql"
 	"io/ioutil"
 	"log"
 	"os"
@@ -62,8 +61,18 @@ const (
 	FmtUnderline                              //下划线格式
 )
 
+type NullMode uint16
+
+const (
+	NullZero      NullMode = iota //默认(和当前行为一致)，NULL 字段仍使用基础类型零值
+	NullSqlNull                   //使用 sql.NullInt64/NullString/NullTime 等
+	NullPointer                   //使用 *int64/*string/*time.Time 等指针类型
+	NullGopkgNull                 //使用 gopkg.in/guregu/null.v4 的 null.Int/null.String 等
+)
+
 type dbStruct struct {
 	dsn              string   //数据库链接
+	driver           string   //数据库驱动(mysql、postgres、sqlite3)
 	tables           []string //自定义表
 	tagJson          bool     //json tag
 	tagOrm           bool     //orm tag
@@ -77,6 +86,17 @@ type dbStruct struct {
 	packageName      string
 	tags             []*Tag
 	db               *sql.DB
+	dialect          Dialect
+	migrationFormat  MigrationFmt
+	migrationPath    string
+	nullMode         NullMode
+	templateName     string                    //当前选用的模板名
+	templateSource   string                    //当前选用的模板内容，为空时使用内置的默认 struct 模板
+	templateSelected bool                      //是否通过 Template/TemplateFile 显式选用过模板
+	outputExt        string                    //输出文件后缀，默认 go
+	tableOverrides   map[string]*TableOverride //通过 LoadConfig 加载的按表个性化配置
+	typeOverrides    map[string]string         //通过 LoadConfig 的 type_map 加载的类型覆盖，key 为小写后的数据库类型名，仅对当前实例生效
+	typeImports      map[string]string         //通过 LoadConfig 的 type_imports 加载的自定义 Go 类型到 import 路径的映射，仅对当前实例生效
 	err              error
 }
 
@@ -89,6 +109,12 @@ func (ds *dbStruct) Dsn(v string) *dbStruct {
 	return ds
 }
 
+// Driver 设置数据库驱动，支持 DriverMySQL、DriverPostgres、DriverSQLite，默认为 DriverMySQL
+func (ds *dbStruct) Driver(v string) *dbStruct {
+	ds.driver = v
+	return ds
+}
+
 func (ds *dbStruct) GenTableName(v string) *dbStruct {
 	ds.genTableName = v
 	return ds
@@ -104,6 +130,65 @@ func (ds *dbStruct) GenTableNameFunc(v bool) *dbStruct {
 	return ds
 }
 
+//MigrationFormat 设置迁移文件的输出格式，配合 MigrationPath 使用
+func (ds *dbStruct) MigrationFormat(v MigrationFmt) *dbStruct {
+	ds.migrationFormat = v
+	return ds
+}
+
+//MigrationPath 设置迁移文件的输出目录，设置后 Generate 会在生成结构体的同时生成迁移文件
+func (ds *dbStruct) MigrationPath(v string) *dbStruct {
+	ds.migrationPath = v
+	return ds
+}
+
+//NullMode 设置可为 NULL 的字段使用哪种方式生成 Go 类型，默认 NullZero
+func (ds *dbStruct) NullMode(v NullMode) *dbStruct {
+	ds.nullMode = v
+	return ds
+}
+
+//Template 选用一个模板，tmpl 为空时按 name 加载内置模板(struct、struct_with_repo、ent_schema、ts_interface)，
+//否则以 tmpl 的内容作为自定义模板
+func (ds *dbStruct) Template(name string, tmpl string) *dbStruct {
+	if tmpl == "" {
+		data, err := builtinTemplateFS.ReadFile(fmt.Sprintf("templates/%s.tmpl", name))
+		if err != nil {
+			ds.err = fmt.Errorf("内置模板%s不存在：%s", name, err.Error())
+			return ds
+		}
+		tmpl = string(data)
+	}
+	ds.templateName = name
+	ds.templateSource = tmpl
+	ds.templateSelected = true
+	if ds.outputExt == "" {
+		if ext, has := builtinTemplateExt[name]; has {
+			ds.outputExt = ext
+		}
+	}
+	return ds
+}
+
+//TemplateFile 从文件加载自定义模板并选用
+func (ds *dbStruct) TemplateFile(path string) *dbStruct {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		ds.err = err
+		return ds
+	}
+	ds.templateName = filepath.Base(path)
+	ds.templateSource = string(data)
+	ds.templateSelected = true
+	return ds
+}
+
+//OutputExt 设置生成文件的后缀名，默认 go，配合非 Go 目标的模板（如 ts_interface）使用
+func (ds *dbStruct) OutputExt(v string) *dbStruct {
+	ds.outputExt = v
+	return ds
+}
+
 func (ds *dbStruct) SingleFile(v bool) *dbStruct {
 	ds.singleFile = v
 	return ds
@@ -153,14 +238,24 @@ func (ds *dbStruct) AppendTag(v *Tag) *dbStruct {
 type Tag struct {
 	TagName string
 	Mode    FmtMode
+	render  func(ds *dbStruct, c column) string
 }
 
 type column struct {
-	Name     string
-	Type     string
-	Nullable string
-	Table    string
-	Comment  string
+	Name            string
+	Type            string
+	RawType         string //数据库原始的完整类型声明，如 varchar(255)、enum('a','b')，建表迁移需要时才会填充
+	Nullable        string
+	Table           string
+	Comment         string
+	IsPrimaryKey    bool
+	IsAutoIncrement bool
+	IsUnique        bool
+	IndexNames      []string
+	Default         sql.NullString
+	MaxLength       sql.NullInt64
+	Precision       sql.NullInt64
+	Scale           sql.NullInt64
 }
 
 func NewTag(tagName string, mode FmtMode) *Tag {
@@ -168,8 +263,17 @@ func NewTag(tagName string, mode FmtMode) *Tag {
 }
 
 func (ds *dbStruct) connectDB() {
+	if ds.driver == "" {
+		ds.driver = DriverMySQL
+	}
+	dialect, has := dialects[ds.driver]
+	if !has {
+		ds.err = fmt.Errorf("不支持的数据库驱动：%s", ds.driver)
+		return
+	}
+	ds.dialect = dialect
 	if ds.db == nil {
-		ds.db, ds.err = sql.Open("mysql", ds.dsn)
+		ds.db, ds.err = ds.dialect.Open(ds.dsn)
 	}
 }
 
@@ -178,6 +282,9 @@ func (ds *dbStruct) Generate() (err error) {
 	if ds.dsn == "" {
 		return errors.New("DSN未配置")
 	}
+	if ds.singleFile && ds.templateSelected {
+		return errors.New("SingleFile 不支持与自定义模板（Template/TemplateFile）同时使用：自定义模板通常自带 package/import 头，多张表拼接到一个文件会产生重复声明")
+	}
 	ds.connectDB()
 	if ds.err != nil {
 		return ds.err
@@ -193,13 +300,53 @@ func (ds *dbStruct) Generate() (err error) {
 	if err != nil {
 		return
 	}
+	if ds.outputExt == "" {
+		ds.outputExt = "go"
+	}
+
 	writes := make(map[string]string)
+	writeImports := make(map[string][]string)
+	allImports := newImportSet()
+	migrationWrites := make(map[string]string)
 	for table, columns := range tables {
-		structName, content, err := ds.genStruct(table, columns)
+		override := ds.tableOverrides[table]
+		columns = ds.filterSkippedColumns(columns, override)
+
+		structName, content, imports, err := ds.renderStruct(table, columns, override)
 		if err != nil {
 			log.Fatalf("%s结构生成失败：%s\n", table, err.Error())
 		}
 		writes[structName] = content
+		writeImports[structName] = imports
+		allImports.add(imports...)
+
+		if ds.migrationPath != "" {
+			filename, migrationContent, err := ds.genMigration(table, columns)
+			if err != nil {
+				log.Fatalf("%s迁移文件生成失败：%s\n", table, err.Error())
+			}
+			migrationWrites[filename] = migrationContent
+		}
+	}
+
+	if ds.migrationPath != "" {
+		err = os.MkdirAll(ds.migrationPath, os.ModePerm)
+		if err != nil {
+			log.Println("migration path create fail.")
+			return err
+		}
+		for filename, content := range migrationWrites {
+			fullPath := fmt.Sprintf("%s/%s", ds.migrationPath, filename)
+			err := ds.writeStruct(fullPath, content)
+			if err != nil {
+				log.Fatalf("write migration fail(%s) : %s ", fullPath, err.Error())
+				continue
+			}
+			if strings.HasSuffix(fullPath, ".go") {
+				cmd := exec.Command("gofmt", "-w", fullPath)
+				_ = cmd.Run()
+			}
+		}
 	}
 
 	if ds.modelPath == "" {
@@ -208,7 +355,7 @@ func (ds *dbStruct) Generate() (err error) {
 			return err
 		}
 		if ds.singleFile {
-			ds.modelPath += "/model/models.go"
+			ds.modelPath += fmt.Sprintf("/model/models.%s", ds.outputExt)
 		} else {
 			ds.modelPath += "/model"
 		}
@@ -229,20 +376,25 @@ func (ds *dbStruct) Generate() (err error) {
 			return err
 		}
 
-		finalContent := bytes.Buffer{}
-		finalContent.WriteString(fmt.Sprintf("package %s\n\n", ds.packageName))
+		body := bytes.Buffer{}
 		for _, content := range writes {
-			finalContent.WriteString(content)
-			finalContent.WriteString("\n\n\n")
+			body.WriteString(content)
+			body.WriteString("\n\n\n")
+		}
+		finalContent := body.String()
+		if !ds.templateSelected {
+			finalContent = buildFileContent(ds.packageName, allImports.slice(), finalContent)
 		}
-		err = ds.writeStruct(ds.modelPath, finalContent.String())
+		err = ds.writeStruct(ds.modelPath, finalContent)
 		if err != nil {
 			log.Fatalf("write struct fail(%s) : %s ", ds.modelPath, err.Error())
 			return err
 		}
 
-		cmd := exec.Command("gofmt", "-w", ds.modelPath)
-		_ = cmd.Run()
+		if ds.outputExt == "go" {
+			cmd := exec.Command("gofmt", "-w", ds.modelPath)
+			_ = cmd.Run()
+		}
 
 	} else {
 
@@ -254,14 +406,20 @@ func (ds *dbStruct) Generate() (err error) {
 
 		for name, content := range writes {
 			filename := ds.getFormatName(name, ds.fileNameFmt)
-			filename = fmt.Sprintf("%s/%s.go", ds.modelPath, filename)
-			err := ds.writeStruct(filename, content)
+			filename = fmt.Sprintf("%s/%s.%s", ds.modelPath, filename, ds.outputExt)
+			fileContent := content
+			if !ds.templateSelected {
+				fileContent = buildFileContent(ds.packageName, writeImports[name], content)
+			}
+			err := ds.writeStruct(filename, fileContent)
 			if err != nil {
 				log.Fatalf("write struct fail(%s) : %s ", filename, err.Error())
 				continue
 			}
-			cmd := exec.Command("gofmt", "-w", filename)
-			_ = cmd.Run()
+			if ds.outputExt == "go" {
+				cmd := exec.Command("gofmt", "-w", filename)
+				_ = cmd.Run()
+			}
 		}
 
 	}
@@ -270,6 +428,11 @@ func (ds *dbStruct) Generate() (err error) {
 }
 
 func (ds *dbStruct) getFormatName(s string, m FmtMode) (res string) {
+	return formatName(s, m)
+}
+
+//formatName 与 getFormatName 等价的无状态版本，供模板函数等不持有 dbStruct 的场景复用
+func formatName(s string, m FmtMode) (res string) {
 	switch m {
 	case FmtUnderlineToStartUpHump:
 		{
@@ -313,90 +476,45 @@ func (ds *dbStruct) getFormatName(s string, m FmtMode) (res string) {
 	return
 }
 
-func (ds *dbStruct) getColumnGoType(dbType string) (res string) {
-	res, has := types[dbType]
-	if !has {
-		res = "string"
-		return
-	}
-	return
-}
-
-func (ds *dbStruct) genStruct(table string, columns []column) (structName string, content string, err error) {
-	buffer := bytes.Buffer{}
-	structName = ds.getFormatName(table, ds.structNameFmt)
-	if !ds.singleFile {
-		buffer.WriteString(fmt.Sprintf("package %s\n\n", ds.packageName))
+func (ds *dbStruct) getColumnGoType(dbType string, nullable bool) (res string) {
+	if goType, has := ds.typeOverrides[strings.ToLower(dbType)]; has {
+		res = goType
+	} else {
+		res = ds.dialect.GoType(dbType)
 	}
-	buffer.WriteString(fmt.Sprintf("type %s struct {\n", structName))
-	for _, column := range columns {
-		columnName := ds.getFormatName(column.Name, ds.fieldNameFmt)
-		goType := ds.getColumnGoType(column.Type)
-		tagString := ""
-		if ds.tags != nil && len(ds.tags) > 0 {
-			tagString = "`"
-			for _, tag := range ds.tags {
-				tagString += fmt.Sprintf("%s:\"%s\" ", tag.TagName, ds.getFormatName(column.Name, tag.Mode))
-			}
-			tagString += "`"
-		}
-		buffer.WriteString(fmt.Sprintf("%s %s %s\n", columnName, goType, tagString))
+	if !nullable || ds.nullMode == NullZero {
+		return res
 	}
-	buffer.WriteString("}\n\n")
-	if ds.genTableNameFunc && ds.genTableName != "" {
-		buffer.WriteString(fmt.Sprintf("func (%s *%s) %s() string {\n\treturn \"%s\"\n}", strings.ToLower(structName[0:1]),
-			structName, ds.genTableName, table))
+	switch ds.nullMode {
+	case NullSqlNull:
+		return sqlNullType(res)
+	case NullPointer:
+		return "*" + res
+	case NullGopkgNull:
+		return gopkgNullType(res)
 	}
-	content = buffer.String()
-	return
+	return res
 }
 
-func (ds *dbStruct) getTables() (tables map[string][]column, err error) {
-	tableIn := ""
-	if ds.tables != nil && len(ds.tables) > 0 {
-		buff := bytes.Buffer{}
-		buff.WriteString("AND TABLE_NAME IN (")
-		for i, tableName := range ds.tables {
-			buff.WriteString("'")
-			buff.WriteString(tableName)
-			buff.WriteString("'")
-			if i != len(ds.tables)-1 {
-				buff.WriteString(", ")
-			}
-		}
-		buff.WriteString(")")
-	}
-	sqlString := fmt.Sprintf("SELECT COLUMN_NAME AS `Name`,DATA_TYPE AS `Type`,IS_NULLABLE AS `Nullable`,TABLE_NAME AS "+
-		"`Table`,COLUMN_COMMENT AS `Comment` FROM information_schema.COLUMNS WHERE table_schema=DATABASE () %s ORDER BY"+
-		" TABLE_NAME ASC", tableIn)
-	rows, err := ds.db.Query(sqlString)
-	if err != nil {
-		return nil, err
+//renderTags 按当前配置的标签渲染出一个字段的结构体 tag 字符串，例如 `json:"id" xorm:"pk autoincr 'id' INT"`
+func (ds *dbStruct) renderTags(c column) string {
+	if len(ds.tags) == 0 {
+		return ""
 	}
-
-	defer func() {
-		qerr := rows.Close()
-		if qerr != nil {
-			log.Fatalf("关闭数据查询结果异常：%s", qerr.Error())
-		}
-	}()
-
-	tables = make(map[string][]column, 3)
-
-	for rows.Next() {
-		c := column{}
-		err := rows.Scan(&c.Name, &c.Type, &c.Nullable, &c.Table, &c.Comment)
-		if err != nil {
-			return nil, err
+	tagString := "`"
+	for _, tag := range ds.tags {
+		value := ds.getFormatName(c.Name, tag.Mode)
+		if tag.render != nil {
+			value = tag.render(ds, c)
 		}
-		_, has := tables[c.Table]
-		if !has {
-			tables[c.Table] = make([]column, 0, 3)
-		}
-		tables[c.Table] = append(tables[c.Table], c)
+		tagString += fmt.Sprintf("%s:\"%s\" ", tag.TagName, value)
 	}
+	tagString += "`"
+	return tagString
+}
 
-	return
+func (ds *dbStruct) getTables() (tables map[string][]column, err error) {
+	return ds.dialect.LoadTables(ds.db, ds.tables)
 }
 
 func (ds *dbStruct) writeStruct(filepath string, content string) (err error) {
@@ -406,4 +524,4 @@ func (ds *dbStruct) writeStruct(filepath string, content string) (err error) {
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
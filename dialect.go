@@ -0,0 +1,471 @@
+package dbstruct
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+//支持的数据库驱动标识
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite3"
+)
+
+//Dialect 定义了不同数据库需要实现的能力：建立连接、读取表结构、类型映射
+type Dialect interface {
+	//Open 根据 dsn 建立数据库连接
+	Open(dsn string) (*sql.DB, error)
+	//LoadTables 读取 filter 指定的表（为空则读取全部表）的字段信息
+	LoadTables(db *sql.DB, filter []string) (map[string][]column, error)
+	//GoType 将数据库类型映射为 Go 类型
+	GoType(dbType string) string
+}
+
+//dialects 已注册的方言实现，以驱动名为 key
+var dialects = map[string]Dialect{
+	DriverMySQL:    &mysqlDialect{},
+	DriverPostgres: &postgresDialect{},
+	DriverSQLite:   &sqliteDialect{},
+}
+
+//quoteNames 拼接 `IN ('a', 'b')` 形式所需的引号列表
+func quoteNames(names []string) string {
+	buff := bytes.Buffer{}
+	for i, name := range names {
+		buff.WriteString("'")
+		buff.WriteString(name)
+		buff.WriteString("'")
+		if i != len(names)-1 {
+			buff.WriteString(", ")
+		}
+	}
+	return buff.String()
+}
+
+//mysqlDialect MySQL 方言实现
+type mysqlDialect struct{}
+
+func (d *mysqlDialect) Open(dsn string) (*sql.DB, error) {
+	return sql.Open(DriverMySQL, dsn)
+}
+
+func (d *mysqlDialect) GoType(dbType string) string {
+	res, has := types[dbType]
+	if !has {
+		return "string"
+	}
+	return res
+}
+
+func (d *mysqlDialect) LoadTables(db *sql.DB, filter []string) (map[string][]column, error) {
+	tableIn := ""
+	if len(filter) > 0 {
+		tableIn = fmt.Sprintf("AND c.TABLE_NAME IN (%s)", quoteNames(filter))
+	}
+	sqlString := fmt.Sprintf("SELECT c.COLUMN_NAME AS `Name`, c.DATA_TYPE AS `Type`, c.COLUMN_TYPE AS `RawType`, "+
+		"c.IS_NULLABLE AS `Nullable`, "+
+		"c.TABLE_NAME AS `Table`, c.COLUMN_COMMENT AS `Comment`, c.COLUMN_DEFAULT AS `Default`, "+
+		"c.CHARACTER_MAXIMUM_LENGTH AS `MaxLength`, c.NUMERIC_PRECISION AS `Precision`, c.NUMERIC_SCALE AS `Scale`, "+
+		"c.EXTRA AS `Extra`, IF(k.CONSTRAINT_NAME = 'PRIMARY', 1, 0) AS `IsPrimaryKey` "+
+		"FROM information_schema.COLUMNS c "+
+		"LEFT JOIN information_schema.KEY_COLUMN_USAGE k "+
+		"ON k.TABLE_SCHEMA = c.TABLE_SCHEMA AND k.TABLE_NAME = c.TABLE_NAME AND k.COLUMN_NAME = c.COLUMN_NAME "+
+		"AND k.CONSTRAINT_NAME = 'PRIMARY' "+
+		"WHERE c.TABLE_SCHEMA = DATABASE() %s ORDER BY c.TABLE_NAME ASC", tableIn)
+
+	rows, err := db.Query(sqlString)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string][]column, 3)
+	for rows.Next() {
+		c := column{}
+		var extra string
+		var isPrimaryKey bool
+		err := rows.Scan(&c.Name, &c.Type, &c.RawType, &c.Nullable, &c.Table, &c.Comment, &c.Default, &c.MaxLength, &c.Precision,
+			&c.Scale, &extra, &isPrimaryKey)
+		if err != nil {
+			return nil, err
+		}
+		c.IsPrimaryKey = isPrimaryKey
+		c.IsAutoIncrement = strings.Contains(extra, "auto_increment")
+		if _, has := tables[c.Table]; !has {
+			tables[c.Table] = make([]column, 0, 3)
+		}
+		tables[c.Table] = append(tables[c.Table], c)
+	}
+
+	if err := d.loadIndexes(db, filter, tables); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+//loadIndexes 通过 information_schema.STATISTICS 补全每个字段所属的索引名及唯一性
+func (d *mysqlDialect) loadIndexes(db *sql.DB, filter []string, tables map[string][]column) error {
+	tableIn := ""
+	if len(filter) > 0 {
+		tableIn = fmt.Sprintf("AND TABLE_NAME IN (%s)", quoteNames(filter))
+	}
+	sqlString := fmt.Sprintf("SELECT TABLE_NAME, COLUMN_NAME, INDEX_NAME, NON_UNIQUE FROM information_schema.STATISTICS "+
+		"WHERE TABLE_SCHEMA = DATABASE() AND INDEX_NAME != 'PRIMARY' %s", tableIn)
+
+	rows, err := db.Query(sqlString)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table, columnName, indexName string
+		var nonUnique int
+		if err := rows.Scan(&table, &columnName, &indexName, &nonUnique); err != nil {
+			return err
+		}
+		columns, has := tables[table]
+		if !has {
+			continue
+		}
+		for i := range columns {
+			if columns[i].Name != columnName {
+				continue
+			}
+			columns[i].IndexNames = append(columns[i].IndexNames, indexName)
+			if nonUnique == 0 {
+				columns[i].IsUnique = true
+			}
+		}
+	}
+	return nil
+}
+
+//postgresTypes pg_catalog/information_schema 类型到 Go 类型的映射
+var postgresTypes = map[string]string{
+	"int2":              "int16",
+	"int4":              "int32",
+	"int8":              "int64",
+	"smallserial":       "int16",
+	"serial":            "int32",
+	"bigserial":         "int64",
+	"numeric":           "float64",
+	"decimal":           "float64",
+	"float4":            "float32",
+	"float8":            "float64",
+	"money":             "string",
+	"bool":              "bool",
+	"varchar":           "string",
+	"character varying": "string",
+	"character":         "string",
+	"bpchar":            "string",
+	"text":              "string",
+	"json":              "string",
+	"jsonb":             "string",
+	"bytea":             "[]byte",
+	"uuid":              "string",
+	"date":              "time.Time",
+	"time":              "time.Time",
+	"timestamp":         "time.Time",
+	"timestamptz":       "time.Time",
+}
+
+//postgresDialect PostgreSQL 方言实现
+type postgresDialect struct{}
+
+func (d *postgresDialect) Open(dsn string) (*sql.DB, error) {
+	return sql.Open(DriverPostgres, dsn)
+}
+
+func (d *postgresDialect) GoType(dbType string) string {
+	res, has := postgresTypes[dbType]
+	if !has {
+		return "string"
+	}
+	return res
+}
+
+func (d *postgresDialect) LoadTables(db *sql.DB, filter []string) (map[string][]column, error) {
+	tableIn := ""
+	if len(filter) > 0 {
+		tableIn = fmt.Sprintf("AND table_name IN (%s)", quoteNames(filter))
+	}
+	sqlString := fmt.Sprintf(`SELECT column_name AS "Name", udt_name AS "Type", is_nullable AS "Nullable", `+
+		`table_name AS "Table", COALESCE(col_description((table_schema || '.' || table_name)::regclass::oid, ordinal_position), '') AS "Comment", `+
+		`column_default AS "Default", character_maximum_length AS "MaxLength", numeric_precision AS "Precision", numeric_scale AS "Scale" `+
+		`FROM information_schema.columns WHERE table_schema = 'public' %s ORDER BY table_name ASC`, tableIn)
+
+	rows, err := db.Query(sqlString)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string][]column, 3)
+	for rows.Next() {
+		c := column{}
+		var columnDefault sql.NullString
+		err := rows.Scan(&c.Name, &c.Type, &c.Nullable, &c.Table, &c.Comment, &columnDefault, &c.MaxLength, &c.Precision, &c.Scale)
+		if err != nil {
+			return nil, err
+		}
+		if columnDefault.Valid && strings.Contains(columnDefault.String, "nextval(") {
+			c.IsAutoIncrement = true
+		} else {
+			c.Default = columnDefault
+		}
+		if _, has := tables[c.Table]; !has {
+			tables[c.Table] = make([]column, 0, 3)
+		}
+		tables[c.Table] = append(tables[c.Table], c)
+	}
+
+	if err := d.loadIndexes(db, filter, tables); err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+//loadIndexes 通过 pg_index/pg_class 系统表补全主键、唯一约束及索引名信息
+func (d *postgresDialect) loadIndexes(db *sql.DB, filter []string, tables map[string][]column) error {
+	tableIn := ""
+	if len(filter) > 0 {
+		tableIn = fmt.Sprintf("AND t.relname IN (%s)", quoteNames(filter))
+	}
+	sqlString := fmt.Sprintf(`SELECT t.relname AS table_name, a.attname AS column_name, i.relname AS index_name, `+
+		`ix.indisunique AS is_unique, ix.indisprimary AS is_primary `+
+		`FROM pg_index ix `+
+		`JOIN pg_class t ON t.oid = ix.indrelid `+
+		`JOIN pg_class i ON i.oid = ix.indexrelid `+
+		`JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey) `+
+		`JOIN pg_namespace n ON n.oid = t.relnamespace `+
+		`WHERE n.nspname = 'public' %s`, tableIn)
+
+	rows, err := db.Query(sqlString)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table, columnName, indexName string
+		var isUnique, isPrimary bool
+		if err := rows.Scan(&table, &columnName, &indexName, &isUnique, &isPrimary); err != nil {
+			return err
+		}
+		columns, has := tables[table]
+		if !has {
+			continue
+		}
+		for i := range columns {
+			if columns[i].Name != columnName {
+				continue
+			}
+			if isPrimary {
+				columns[i].IsPrimaryKey = true
+				continue
+			}
+			columns[i].IndexNames = append(columns[i].IndexNames, indexName)
+			if isUnique {
+				columns[i].IsUnique = true
+			}
+		}
+	}
+	return nil
+}
+
+//sqliteTypes SQLite 类型亲和性到 Go 类型的映射
+var sqliteTypes = map[string]string{
+	"INTEGER":  "int64",
+	"REAL":     "float64",
+	"TEXT":     "string",
+	"BLOB":     "[]byte",
+	"NUMERIC":  "float64",
+	"BOOLEAN":  "bool",
+	"DATETIME": "time.Time",
+}
+
+//sqliteDialect SQLite 方言实现，通过 PRAGMA table_info 读取表结构
+type sqliteDialect struct{}
+
+func (d *sqliteDialect) Open(dsn string) (*sql.DB, error) {
+	return sql.Open(DriverSQLite, dsn)
+}
+
+//GoType 先查 sqliteTypes 中声明类型的精确匹配（如内置的 DATETIME/BOOLEAN），查不到时按 SQLite 的类型亲和性规则推断；type_map 覆盖由 getColumnGoType 在调用本方法之前处理
+func (d *sqliteDialect) GoType(dbType string) string {
+	upper := strings.ToUpper(dbType)
+	if res, has := sqliteTypes[upper]; has {
+		return res
+	}
+	switch {
+	case strings.Contains(upper, "INT"):
+		return "int64"
+	case strings.Contains(upper, "CHAR"), strings.Contains(upper, "CLOB"), strings.Contains(upper, "TEXT"):
+		return "string"
+	case strings.Contains(upper, "BLOB"), upper == "":
+		return "[]byte"
+	case strings.Contains(upper, "REAL"), strings.Contains(upper, "FLOA"), strings.Contains(upper, "DOUB"):
+		return "float64"
+	default:
+		return "float64" //未命中任何亲和性关键字时落入 SQLite 的 NUMERIC 亲和性
+	}
+}
+
+func (d *sqliteDialect) LoadTables(db *sql.DB, filter []string) (tables map[string][]column, err error) {
+	tableNames, err := d.listTables(db, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	tables = make(map[string][]column, len(tableNames))
+	for _, table := range tableNames {
+		rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+		if err != nil {
+			return nil, err
+		}
+		cols, err := d.readPragmaColumns(rows, table)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.loadIndexes(db, table, cols); err != nil {
+			return nil, err
+		}
+		tables[table] = cols
+	}
+	return tables, nil
+}
+
+//loadIndexes 通过 PRAGMA index_list/index_info 补全唯一约束及索引名信息
+func (d *sqliteDialect) loadIndexes(db *sql.DB, table string, cols []column) error {
+	listRows, err := db.Query(fmt.Sprintf("PRAGMA index_list(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer listRows.Close()
+
+	type indexMeta struct {
+		name   string
+		unique bool
+	}
+	indexes := make([]indexMeta, 0, 2)
+	for listRows.Next() {
+		var seq int
+		var name, origin string
+		var unique, partial int
+		if err := listRows.Scan(&seq, &name, &unique, &origin, &partial); err != nil {
+			return err
+		}
+		indexes = append(indexes, indexMeta{name: name, unique: unique == 1})
+	}
+
+	for _, idx := range indexes {
+		infoRows, err := db.Query(fmt.Sprintf("PRAGMA index_info(%s)", idx.name))
+		if err != nil {
+			return err
+		}
+		for infoRows.Next() {
+			var seqno, cid int
+			var columnName string
+			if err := infoRows.Scan(&seqno, &cid, &columnName); err != nil {
+				infoRows.Close()
+				return err
+			}
+			for i := range cols {
+				if cols[i].Name != columnName {
+					continue
+				}
+				cols[i].IndexNames = append(cols[i].IndexNames, idx.name)
+				if idx.unique {
+					cols[i].IsUnique = true
+				}
+			}
+		}
+		infoRows.Close()
+	}
+	return nil
+}
+
+func (d *sqliteDialect) listTables(db *sql.DB, filter []string) ([]string, error) {
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type='table' ORDER BY name ASC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allow := make(map[string]bool, len(filter))
+	for _, name := range filter {
+		allow[name] = true
+	}
+
+	names := make([]string, 0, 3)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		if len(allow) > 0 && !allow[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (d *sqliteDialect) readPragmaColumns(rows *sql.Rows, table string) ([]column, error) {
+	defer rows.Close()
+
+	cols := make([]column, 0, 3)
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return nil, err
+		}
+		nullable := "YES"
+		if notNull == 1 {
+			nullable = "NO"
+		}
+		cols = append(cols, column{
+			Name:            name,
+			Type:            colType,
+			Nullable:        nullable,
+			Table:           table,
+			Default:         dfltValue,
+			IsPrimaryKey:    pk > 0,
+			IsAutoIncrement: pk > 0 && strings.EqualFold(colType, "INTEGER"),
+			MaxLength:       parseDeclaredLength(colType),
+		})
+	}
+	return cols, nil
+}
+
+//parseDeclaredLength 从形如 VARCHAR(255) 的声明类型中解析出长度，未声明长度时返回无效值
+func parseDeclaredLength(declared string) sql.NullInt64 {
+	start := strings.Index(declared, "(")
+	end := strings.Index(declared, ")")
+	if start < 0 || end <= start {
+		return sql.NullInt64{}
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(declared[start+1 : end]))
+	if err != nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(n), Valid: true}
+}
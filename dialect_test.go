@@ -0,0 +1,47 @@
+package dbstruct
+
+import "testing"
+
+func TestParseDeclaredLength(t *testing.T) {
+	cases := []struct {
+		declared   string
+		wantValid  bool
+		wantLength int64
+	}{
+		{"VARCHAR(255)", true, 255},
+		{"DECIMAL(10,2)", false, 0}, //括号内含逗号，非单纯长度声明，Atoi 解析失败
+		{"TEXT", false, 0},
+		{"INT", false, 0},
+		{"VARCHAR()", false, 0},
+	}
+	for _, tc := range cases {
+		got := parseDeclaredLength(tc.declared)
+		if got.Valid != tc.wantValid {
+			t.Errorf("parseDeclaredLength(%q).Valid = %v, want %v", tc.declared, got.Valid, tc.wantValid)
+			continue
+		}
+		if got.Valid && got.Int64 != tc.wantLength {
+			t.Errorf("parseDeclaredLength(%q).Int64 = %d, want %d", tc.declared, got.Int64, tc.wantLength)
+		}
+	}
+}
+
+func TestSqliteDialectGoType(t *testing.T) {
+	d := &sqliteDialect{}
+	cases := []struct {
+		declared string
+		want     string
+	}{
+		{"BIGINT", "int64"},
+		{"VARCHAR(255)", "string"},
+		{"DOUBLE", "float64"},
+		{"DECIMAL(10,2)", "float64"},
+		{"DATETIME", "time.Time"}, //内置精确匹配优先于亲和性规则
+		{"BOOLEAN", "bool"},
+	}
+	for _, tc := range cases {
+		if got := d.GoType(tc.declared); got != tc.want {
+			t.Errorf("sqliteDialect.GoType(%q) = %q, want %q", tc.declared, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package dbstruct
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//importSet 收集单个（或合并后）文件所需的 import 行，自动去重
+type importSet map[string]struct{}
+
+func newImportSet() importSet {
+	return make(importSet)
+}
+
+func (s importSet) add(lines ...string) {
+	for _, line := range lines {
+		s[line] = struct{}{}
+	}
+}
+
+func (s importSet) merge(other importSet) {
+	for line := range other {
+		s[line] = struct{}{}
+	}
+}
+
+func (s importSet) slice() []string {
+	res := make([]string, 0, len(s))
+	for line := range s {
+		res = append(res, line)
+	}
+	sort.Strings(res)
+	return res
+}
+
+//columnImports 根据生成的字段类型推断需要引入的包，custom 为通过 LoadConfig 的 type_imports 加载的按实例覆盖
+func columnImports(goType string, custom map[string]string) []string {
+	imports := make([]string, 0, 2)
+	if strings.Contains(goType, "time.Time") {
+		imports = append(imports, "time")
+	}
+	if strings.Contains(goType, "sql.") {
+		imports = append(imports, "database/sql")
+	}
+	if strings.Contains(goType, "null.") {
+		imports = append(imports, "null \"gopkg.in/guregu/null.v4\"")
+	}
+	if imp, has := custom[goType]; has {
+		imports = append(imports, imp)
+	}
+	return imports
+}
+
+//buildFileContent 拼出完整的 Go 文件内容：package 声明、import 块（如需要）、正文
+func buildFileContent(packageName string, imports []string, body string) string {
+	buffer := bytes.Buffer{}
+	buffer.WriteString(fmt.Sprintf("package %s\n\n", packageName))
+	if len(imports) > 0 {
+		buffer.WriteString("import (\n")
+		for _, imp := range imports {
+			buffer.WriteString(fmt.Sprintf("\t%s\n", importLine(imp)))
+		}
+		buffer.WriteString(")\n\n")
+	}
+	buffer.WriteString(body)
+	return buffer.String()
+}
+
+//importLine 除带别名的 import（如 null "gopkg.in/guregu/null.v4"）外，其余路径加上双引号
+func importLine(imp string) string {
+	if strings.Contains(imp, "\"") {
+		return imp
+	}
+	return fmt.Sprintf("\"%s\"", imp)
+}
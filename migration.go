@@ -0,0 +1,198 @@
+package dbstruct
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+type MigrationFmt uint16
+
+const (
+	FmtGoose      MigrationFmt = iota //goose 格式的 SQL 迁移文件
+	FmtXormigrate                     //xormigrate 格式的 Go 迁移文件
+	FmtSQL                            //原生 up/down SQL 迁移文件
+)
+
+//genMigration 根据表结构生成迁移文件名及内容
+func (ds *dbStruct) genMigration(table string, columns []column) (filename string, content string, err error) {
+	up := ds.buildCreateTableSQL(table, columns)
+	down := fmt.Sprintf("DROP TABLE %s;", table)
+
+	switch ds.migrationFormat {
+	case FmtXormigrate:
+		filename = fmt.Sprintf("%s_migration.go", table)
+		content = ds.renderXormigrate(table, up, down)
+	case FmtSQL:
+		filename = fmt.Sprintf("%s_migration.sql", table)
+		content = fmt.Sprintf("-- +migrate Up\n%s\n\n-- +migrate Down\n%s\n", up, down)
+	default:
+		filename = fmt.Sprintf("%s_migration.sql", table)
+		content = fmt.Sprintf("-- +goose Up\n%s\n\n-- +goose Down\n%s\n", up, down)
+	}
+	return
+}
+
+//variableLengthTypes 只有这些类型的长度声明才是合法 DDL，例如 TEXT/ENUM 的 CHARACTER_MAXIMUM_LENGTH 不能直接拼成 (n)
+var variableLengthTypes = map[string]bool{
+	"VARCHAR":   true,
+	"CHAR":      true,
+	"VARBINARY": true,
+	"BINARY":    true,
+}
+
+//buildCreateTableSQL 按当前方言拼出建表语句：AUTO_INCREMENT、内联 COMMENT、类型大小写等写法三种数据库并不通用，分别在各自的 buildXxxCreateTableSQL 中处理
+func (ds *dbStruct) buildCreateTableSQL(table string, columns []column) string {
+	switch ds.driver {
+	case DriverPostgres:
+		return ds.buildPostgresCreateTableSQL(table, columns)
+	case DriverSQLite:
+		return ds.buildSQLiteCreateTableSQL(table, columns)
+	default:
+		return ds.buildMySQLCreateTableSQL(table, columns)
+	}
+}
+
+//buildMySQLCreateTableSQL 生成 MySQL 建表语句，列类型、主键沿用 information_schema 读取到的原始信息
+func (ds *dbStruct) buildMySQLCreateTableSQL(table string, columns []column) string {
+	buffer := bytes.Buffer{}
+	buffer.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", table))
+
+	defs := make([]string, 0, len(columns))
+	pk := make([]string, 0, 1)
+	for _, c := range columns {
+		upperType := strings.ToUpper(c.Type)
+		var def string
+		switch upperType {
+		case "ENUM", "SET":
+			if c.RawType != "" {
+				def = fmt.Sprintf("  %s %s", c.Name, c.RawType)
+			} else {
+				def = fmt.Sprintf("  %s %s", c.Name, upperType)
+			}
+		default:
+			def = fmt.Sprintf("  %s %s", c.Name, upperType)
+			if c.MaxLength.Valid && variableLengthTypes[upperType] {
+				def += fmt.Sprintf("(%d)", c.MaxLength.Int64)
+			}
+		}
+		if c.Nullable == "NO" {
+			def += " NOT NULL"
+		}
+		if c.IsAutoIncrement {
+			def += " AUTO_INCREMENT"
+		}
+		if c.Default.Valid {
+			def += fmt.Sprintf(" DEFAULT '%s'", c.Default.String)
+		}
+		if c.Comment != "" {
+			def += fmt.Sprintf(" COMMENT '%s'", c.Comment)
+		}
+		defs = append(defs, def)
+		if c.IsPrimaryKey {
+			pk = append(pk, c.Name)
+		}
+	}
+	if len(pk) > 0 {
+		defs = append(defs, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pk, ", ")))
+	}
+
+	buffer.WriteString(strings.Join(defs, ",\n"))
+	buffer.WriteString("\n);")
+	return buffer.String()
+}
+
+//buildPostgresCreateTableSQL 生成 PostgreSQL 建表语句；PostgreSQL 没有内联 COMMENT 语法，改为建表后追加 COMMENT ON COLUMN 语句，自增列使用 GENERATED BY DEFAULT AS IDENTITY
+func (ds *dbStruct) buildPostgresCreateTableSQL(table string, columns []column) string {
+	buffer := bytes.Buffer{}
+	buffer.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", table))
+
+	defs := make([]string, 0, len(columns))
+	pk := make([]string, 0, 1)
+	comments := make([]string, 0, 1)
+	for _, c := range columns {
+		def := fmt.Sprintf("  %s %s", c.Name, c.Type)
+		if c.MaxLength.Valid && strings.Contains(c.Type, "char") {
+			def += fmt.Sprintf("(%d)", c.MaxLength.Int64)
+		}
+		if c.Nullable == "NO" {
+			def += " NOT NULL"
+		}
+		if c.IsAutoIncrement {
+			def += " GENERATED BY DEFAULT AS IDENTITY"
+		} else if c.Default.Valid {
+			def += fmt.Sprintf(" DEFAULT '%s'", c.Default.String)
+		}
+		defs = append(defs, def)
+		if c.IsPrimaryKey {
+			pk = append(pk, c.Name)
+		}
+		if c.Comment != "" {
+			comments = append(comments, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS '%s';", table, c.Name, c.Comment))
+		}
+	}
+	if len(pk) > 0 {
+		defs = append(defs, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pk, ", ")))
+	}
+
+	buffer.WriteString(strings.Join(defs, ",\n"))
+	buffer.WriteString("\n);")
+	for _, c := range comments {
+		buffer.WriteString("\n")
+		buffer.WriteString(c)
+	}
+	return buffer.String()
+}
+
+//buildSQLiteCreateTableSQL 生成 SQLite 建表语句；SQLite 不支持列注释，自增列须写成 INTEGER PRIMARY KEY AUTOINCREMENT 且不能再出现在末尾的 PRIMARY KEY 子句中
+func (ds *dbStruct) buildSQLiteCreateTableSQL(table string, columns []column) string {
+	buffer := bytes.Buffer{}
+	buffer.WriteString(fmt.Sprintf("CREATE TABLE %s (\n", table))
+
+	defs := make([]string, 0, len(columns))
+	pk := make([]string, 0, 1)
+	for _, c := range columns {
+		upperType := strings.ToUpper(c.Type)
+		def := fmt.Sprintf("  %s %s", c.Name, upperType)
+		if c.IsPrimaryKey && c.IsAutoIncrement {
+			def += " PRIMARY KEY AUTOINCREMENT"
+		} else {
+			if c.Nullable == "NO" {
+				def += " NOT NULL"
+			}
+			if c.Default.Valid {
+				def += fmt.Sprintf(" DEFAULT '%s'", c.Default.String)
+			}
+			if c.IsPrimaryKey {
+				pk = append(pk, c.Name)
+			}
+		}
+		defs = append(defs, def)
+	}
+	if len(pk) > 0 {
+		defs = append(defs, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pk, ", ")))
+	}
+
+	buffer.WriteString(strings.Join(defs, ",\n"))
+	buffer.WriteString("\n);")
+	return buffer.String()
+}
+
+//renderXormigrate 生成一个注册到 Migrations 列表的 xormigrate 迁移文件
+func (ds *dbStruct) renderXormigrate(table, up, down string) string {
+	return fmt.Sprintf("package %s\n\n"+
+		"import \"github.com/go-xorm/xorm\"\n\n"+
+		"func init() {\n"+
+		"\tMigrations = append(Migrations, &Migration{\n"+
+		"\t\tID: \"%s_migration\",\n"+
+		"\t\tMigrate: func(tx *xorm.Engine) error {\n"+
+		"\t\t\t_, err := tx.Exec(`%s`)\n"+
+		"\t\t\treturn err\n"+
+		"\t\t},\n"+
+		"\t\tRollback: func(tx *xorm.Engine) error {\n"+
+		"\t\t\t_, err := tx.Exec(`%s`)\n"+
+		"\t\t\treturn err\n"+
+		"\t\t},\n"+
+		"\t})\n"+
+		"}\n", ds.packageName, table, up, down)
+}
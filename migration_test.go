@@ -0,0 +1,55 @@
+package dbstruct
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+func TestBuildCreateTableSQL(t *testing.T) {
+	cols := []column{
+		{Name: "id", Type: "bigint", Nullable: "NO", IsPrimaryKey: true, IsAutoIncrement: true},
+		{Name: "name", Type: "varchar", Nullable: "NO", MaxLength: sql.NullInt64{Int64: 64, Valid: true}, Comment: "姓名"},
+	}
+
+	cases := []struct {
+		name     string
+		driver   string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "mysql uses AUTO_INCREMENT and inline COMMENT",
+			driver:   DriverMySQL,
+			contains: []string{"AUTO_INCREMENT", "VARCHAR(64)", "COMMENT '姓名'", "PRIMARY KEY (id)"},
+		},
+		{
+			name:     "postgres uses GENERATED BY DEFAULT AS IDENTITY and separate COMMENT ON COLUMN",
+			driver:   DriverPostgres,
+			contains: []string{"GENERATED BY DEFAULT AS IDENTITY", "COMMENT ON COLUMN t.name IS"},
+			excludes: []string{"AUTO_INCREMENT"},
+		},
+		{
+			name:     "sqlite uses inline PRIMARY KEY AUTOINCREMENT and skips comments",
+			driver:   DriverSQLite,
+			contains: []string{"PRIMARY KEY AUTOINCREMENT"},
+			excludes: []string{"COMMENT", "AUTO_INCREMENT"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := &dbStruct{driver: tc.driver}
+			sqlText := ds.buildCreateTableSQL("t", cols)
+			for _, want := range tc.contains {
+				if !strings.Contains(sqlText, want) {
+					t.Errorf("expected SQL to contain %q, got:\n%s", want, sqlText)
+				}
+			}
+			for _, unwanted := range tc.excludes {
+				if strings.Contains(sqlText, unwanted) {
+					t.Errorf("expected SQL to not contain %q, got:\n%s", unwanted, sqlText)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package dbstruct
+
+//sqlNullTypes 基础 Go 类型到 database/sql Null* 类型的映射
+var sqlNullTypes = map[string]string{
+	"int":       "sql.NullInt64",
+	"int8":      "sql.NullInt16",
+	"int16":     "sql.NullInt16",
+	"int32":     "sql.NullInt32",
+	"int64":     "sql.NullInt64",
+	"float32":   "sql.NullFloat64",
+	"float64":   "sql.NullFloat64",
+	"bool":      "sql.NullBool",
+	"string":    "sql.NullString",
+	"time.Time": "sql.NullTime",
+}
+
+//gopkgNullTypes 基础 Go 类型到 gopkg.in/guregu/null.v4 类型的映射
+var gopkgNullTypes = map[string]string{
+	"int":       "null.Int",
+	"int8":      "null.Int",
+	"int16":     "null.Int",
+	"int32":     "null.Int",
+	"int64":     "null.Int",
+	"float32":   "null.Float",
+	"float64":   "null.Float",
+	"bool":      "null.Bool",
+	"string":    "null.String",
+	"time.Time": "null.Time",
+}
+
+func sqlNullType(goType string) string {
+	res, has := sqlNullTypes[goType]
+	if !has {
+		return goType
+	}
+	return res
+}
+
+func gopkgNullType(goType string) string {
+	res, has := gopkgNullTypes[goType]
+	if !has {
+		return goType
+	}
+	return res
+}
@@ -0,0 +1,37 @@
+package dbstruct
+
+import "testing"
+
+func TestSqlNullType(t *testing.T) {
+	cases := []struct {
+		goType string
+		want   string
+	}{
+		{"int64", "sql.NullInt64"},
+		{"string", "sql.NullString"},
+		{"time.Time", "sql.NullTime"},
+		{"[]byte", "[]byte"}, //未登记的类型原样返回
+	}
+	for _, tc := range cases {
+		if got := sqlNullType(tc.goType); got != tc.want {
+			t.Errorf("sqlNullType(%q) = %q, want %q", tc.goType, got, tc.want)
+		}
+	}
+}
+
+func TestGopkgNullType(t *testing.T) {
+	cases := []struct {
+		goType string
+		want   string
+	}{
+		{"int64", "null.Int"},
+		{"string", "null.String"},
+		{"time.Time", "null.Time"},
+		{"[]byte", "[]byte"}, //未登记的类型原样返回
+	}
+	for _, tc := range cases {
+		if got := gopkgNullType(tc.goType); got != tc.want {
+			t.Errorf("gopkgNullType(%q) = %q, want %q", tc.goType, got, tc.want)
+		}
+	}
+}
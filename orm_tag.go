@@ -0,0 +1,68 @@
+package dbstruct
+
+import (
+	"fmt"
+	"strings"
+)
+
+//ORMFlavor 标识生成 ORM 标签时采用的风格
+type ORMFlavor uint16
+
+const (
+	XormFlavor ORMFlavor = iota //xorm 风格
+	GormFlavor                  //gorm 风格
+)
+
+//NewORMTag 创建一个按 flavor 渲染内容的 ORM 标签，而非简单的字段名格式化
+func NewORMTag(tagName string, flavor ORMFlavor) *Tag {
+	render := xormTagValue
+	if flavor == GormFlavor {
+		render = gormTagValue
+	}
+	return &Tag{TagName: tagName, render: render}
+}
+
+//xormTagValue 生成形如 `pk autoincr 'id' BIGINT` 的 xorm 标签内容
+func xormTagValue(ds *dbStruct, c column) string {
+	parts := make([]string, 0, 5)
+	if c.IsPrimaryKey {
+		parts = append(parts, "pk")
+	}
+	if c.IsAutoIncrement {
+		parts = append(parts, "autoincr")
+	}
+	if c.IsUnique {
+		parts = append(parts, "unique")
+	}
+	if c.Nullable == "NO" && !c.IsPrimaryKey {
+		parts = append(parts, "notnull")
+	}
+	parts = append(parts, fmt.Sprintf("'%s'", c.Name))
+	parts = append(parts, strings.ToUpper(c.Type))
+	return strings.Join(parts, " ")
+}
+
+//gormTagValue 生成形如 `column:id;primaryKey;size:255;index:idx_name` 的 gorm 标签内容
+func gormTagValue(ds *dbStruct, c column) string {
+	parts := make([]string, 0, 6)
+	parts = append(parts, fmt.Sprintf("column:%s", c.Name))
+	if c.IsPrimaryKey {
+		parts = append(parts, "primaryKey")
+	}
+	if c.IsAutoIncrement {
+		parts = append(parts, "autoIncrement")
+	}
+	if c.IsUnique {
+		parts = append(parts, "unique")
+	}
+	if c.MaxLength.Valid {
+		parts = append(parts, fmt.Sprintf("size:%d", c.MaxLength.Int64))
+	}
+	for _, indexName := range c.IndexNames {
+		parts = append(parts, fmt.Sprintf("index:%s", indexName))
+	}
+	if c.Nullable == "NO" && !c.IsPrimaryKey {
+		parts = append(parts, "not null")
+	}
+	return strings.Join(parts, ";")
+}
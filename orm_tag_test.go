@@ -0,0 +1,72 @@
+package dbstruct
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestXormTagValue(t *testing.T) {
+	cases := []struct {
+		name string
+		c    column
+		want string
+	}{
+		{
+			name: "primary key autoincrement",
+			c:    column{Name: "id", Type: "bigint", IsPrimaryKey: true, IsAutoIncrement: true, Nullable: "NO"},
+			want: "pk autoincr 'id' BIGINT",
+		},
+		{
+			name: "unique not null",
+			c:    column{Name: "email", Type: "varchar", IsUnique: true, Nullable: "NO"},
+			want: "unique notnull 'email' VARCHAR",
+		},
+		{
+			name: "plain nullable column",
+			c:    column{Name: "remark", Type: "text", Nullable: "YES"},
+			want: "'remark' TEXT",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := xormTagValue(nil, tc.c); got != tc.want {
+				t.Errorf("xormTagValue() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGormTagValue(t *testing.T) {
+	cases := []struct {
+		name string
+		c    column
+		want string
+	}{
+		{
+			name: "primary key autoincrement",
+			c:    column{Name: "id", IsPrimaryKey: true, IsAutoIncrement: true, Nullable: "NO"},
+			want: "column:id;primaryKey;autoIncrement",
+		},
+		{
+			name: "unique with size and index",
+			c: column{
+				Name: "email", IsUnique: true, Nullable: "NO",
+				MaxLength:  sql.NullInt64{Int64: 255, Valid: true},
+				IndexNames: []string{"idx_email"},
+			},
+			want: "column:email;unique;size:255;index:idx_email;not null",
+		},
+		{
+			name: "plain nullable column",
+			c:    column{Name: "remark", Nullable: "YES"},
+			want: "column:remark",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := gormTagValue(nil, tc.c); got != tc.want {
+				t.Errorf("gormTagValue() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package dbstruct
+
+import (
+	"bytes"
+	"embed"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplateFS embed.FS
+
+//builtinTemplateExt 内置模板对应的默认输出后缀，未列出的模板默认使用 go
+var builtinTemplateExt = map[string]string{
+	"ts_interface": "ts",
+}
+
+//builtinTemplateImports 内置模板自身已经硬编码的 import，渲染时需要从收集到的 .Imports 中去重，避免重复 import
+var builtinTemplateImports = map[string][]string{
+	"struct_with_repo": {"database/sql"},
+}
+
+var templateFuncs = template.FuncMap{
+	"camel":      func(s string) string { return formatName(s, FmtUnderlineToStartLowHump) },
+	"pascal":     func(s string) string { return formatName(s, FmtUnderlineToStartUpHump) },
+	"snake":      func(s string) string { return formatName(s, FmtUnderline) },
+	"importLine": importLine,
+	"entField":   entFieldFunc,
+	"tsType":     tsTypeFunc,
+}
+
+//templateField 是单个字段提供给模板的数据
+type templateField struct {
+	Name     string //数据库字段名
+	GoName   string //生成的 Go 字段名
+	GoType   string //生成的 Go 类型
+	DBType   string //数据库原始类型
+	Nullable bool
+	Comment  string
+	IsPK     bool
+	Tags     string //渲染好的结构体 tag，如 `json:"id"`
+}
+
+//templateContext 是生成单张表时传给模板的完整上下文
+type templateContext struct {
+	PackageName   string
+	StructName    string
+	TableName     string
+	Receiver      string //结构体方法的接收者变量名
+	TableNameFunc string //TableName 方法名，为空表示不生成
+	Imports       []string
+	Fields        []templateField
+}
+
+//currentTemplate 解析当前选用的模板，未显式选用时使用内置的默认 struct 模板（仅正文，不含 package/import）
+func (ds *dbStruct) currentTemplate() (*template.Template, error) {
+	source := ds.templateSource
+	name := ds.templateName
+	if source == "" {
+		data, err := builtinTemplateFS.ReadFile("templates/struct.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		source = string(data)
+		name = "struct"
+	}
+	return template.New(name).Funcs(templateFuncs).Parse(source)
+}
+
+//renderStruct 将一张表渲染为结构体（或其他目标语言的等价物）文件内容，override 为该表通过 LoadConfig 设置的个性化规则，可为 nil
+func (ds *dbStruct) renderStruct(table string, columns []column, override *TableOverride) (structName string, content string, imports []string, err error) {
+	structName = ds.getFormatName(table, ds.structNameFmt)
+	if override != nil && override.StructName != "" {
+		structName = override.StructName
+	}
+	fileImports := newImportSet()
+
+	fields := make([]templateField, 0, len(columns))
+	for _, c := range columns {
+		goType := ds.getColumnGoType(c.Type, c.Nullable == "YES")
+		if override != nil && override.ColumnTypes[c.Name] != "" {
+			goType = override.ColumnTypes[c.Name]
+		}
+		fileImports.add(columnImports(goType, ds.typeImports)...)
+		tags := ds.renderTags(c)
+		if override != nil && override.ExtraTags[c.Name] != "" {
+			tags = appendTagExtra(tags, override.ExtraTags[c.Name])
+		}
+		fields = append(fields, templateField{
+			Name:     c.Name,
+			GoName:   ds.getFormatName(c.Name, ds.fieldNameFmt),
+			GoType:   goType,
+			DBType:   c.Type,
+			Nullable: c.Nullable == "YES",
+			Comment:  c.Comment,
+			IsPK:     c.IsPrimaryKey,
+			Tags:     tags,
+		})
+	}
+
+	for _, builtin := range builtinTemplateImports[ds.templateName] {
+		delete(fileImports, builtin)
+	}
+
+	ctx := templateContext{
+		PackageName: ds.packageName,
+		StructName:  structName,
+		TableName:   table,
+		Receiver:    strings.ToLower(structName[0:1]),
+		Imports:     fileImports.slice(),
+		Fields:      fields,
+	}
+	if ds.genTableNameFunc && ds.genTableName != "" {
+		ctx.TableNameFunc = ds.genTableName
+	}
+
+	tmpl, err := ds.currentTemplate()
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	buffer := bytes.Buffer{}
+	if err := tmpl.Execute(&buffer, ctx); err != nil {
+		return "", "", nil, err
+	}
+
+	content = buffer.String()
+	imports = fileImports.slice()
+	return
+}
+
+//entFieldFunc 将 Go 类型映射为 ent 的 field 构造函数名
+func entFieldFunc(goType string) string {
+	switch {
+	case strings.Contains(goType, "int"):
+		return "Int64"
+	case strings.Contains(goType, "float"):
+		return "Float64"
+	case strings.Contains(goType, "bool"):
+		return "Bool"
+	case strings.Contains(goType, "time.Time"):
+		return "Time"
+	case strings.Contains(goType, "[]byte"):
+		return "Bytes"
+	default:
+		return "String"
+	}
+}
+
+//tsTypeFunc 将 Go 类型映射为 TypeScript 类型
+func tsTypeFunc(goType string) string {
+	switch {
+	case strings.Contains(goType, "int"), strings.Contains(goType, "float"):
+		return "number"
+	case strings.Contains(goType, "bool"):
+		return "boolean"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,45 @@
+package dbstruct
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderStruct(t *testing.T) {
+	ds := &dbStruct{
+		dialect:       &mysqlDialect{},
+		packageName:   "models",
+		structNameFmt: FmtUnderlineToStartUpHump,
+		fieldNameFmt:  FmtUnderlineToStartUpHump,
+	}
+	cols := []column{
+		{Name: "id", Type: "bigint", Nullable: "NO", IsPrimaryKey: true},
+		{Name: "created_at", Type: "datetime", Nullable: "YES"},
+	}
+
+	structName, content, imports, err := ds.renderStruct("user", cols, nil)
+	if err != nil {
+		t.Fatalf("renderStruct() error = %v", err)
+	}
+	if structName != "User" {
+		t.Errorf("structName = %q, want %q", structName, "User")
+	}
+	if !strings.Contains(content, "type User struct") {
+		t.Errorf("content missing struct declaration:\n%s", content)
+	}
+	if !strings.Contains(content, "Id int64") {
+		t.Errorf("content missing Id field:\n%s", content)
+	}
+	if !strings.Contains(content, "CreatedAt time.Time") {
+		t.Errorf("content missing CreatedAt field:\n%s", content)
+	}
+	found := false
+	for _, imp := range imports {
+		if imp == "time" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("imports = %v, want to contain %q", imports, "time")
+	}
+}